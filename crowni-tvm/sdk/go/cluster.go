@@ -0,0 +1,103 @@
+package crowny
+
+import (
+	"context"
+	"time"
+)
+
+// ═══════════════════════════════════════════════
+// Cluster
+// ═══════════════════════════════════════════════
+
+// RetryPolicy controls how an HTTPTransport retries across cluster
+// endpoints.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of endpoint attempts Do will make
+	// before giving up. 0 means "try each endpoint once".
+	MaxAttempts int
+
+	// BackoffBase is the base delay before retrying; left to callers to
+	// apply between their own Submit calls, since Do itself rotates
+	// endpoints immediately rather than sleeping.
+	BackoffBase time.Duration
+
+	// RetryOnStates lists TritValue states that should trigger a retry
+	// against the next endpoint even though the server responded. Only
+	// O is meaningful here: T is a semantic failure, and retrying it
+	// would mask a real "no" from the task itself.
+	RetryOnStates []TritValue
+}
+
+// DefaultRetryPolicy tries every configured endpoint once and does not
+// retry on semantic O responses.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 0,
+		BackoffBase: 250 * time.Millisecond,
+	}
+}
+
+func (p RetryPolicy) shouldRetry(state TritValue) bool {
+	for _, s := range p.RetryOnStates {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// Endpoints returns the configured endpoints in pinned order. Returns
+// nil if the Client's Transport is not an HTTPTransport.
+func (c *Client) Endpoints() []string {
+	t, ok := c.transport.(*HTTPTransport)
+	if !ok {
+		return nil
+	}
+	return t.Endpoints()
+}
+
+// EndpointHealth returns the last-observed health of each endpoint.
+// Returns nil if the Client's Transport is not an HTTPTransport.
+func (c *Client) EndpointHealth() map[string]TritValue {
+	t, ok := c.transport.(*HTTPTransport)
+	if !ok {
+		return nil
+	}
+	return t.EndpointHealth()
+}
+
+// WithEndpoints replaces the client's endpoint list. A no-op if the
+// Client's Transport is not an HTTPTransport.
+func (c *Client) WithEndpoints(endpoints []string) *Client {
+	if t, ok := c.transport.(*HTTPTransport); ok {
+		t.SetEndpoints(endpoints)
+	}
+	return c
+}
+
+// WithRetryPolicy sets the RetryPolicy used by an HTTPTransport. A
+// no-op if the Client's Transport is not an HTTPTransport.
+func (c *Client) WithRetryPolicy(p RetryPolicy) *Client {
+	if t, ok := c.transport.(*HTTPTransport); ok {
+		t.SetRetryPolicy(p)
+	}
+	return c
+}
+
+// Sync refreshes EndpointHealth by pinging every configured endpoint
+// once. A no-op if the Client's Transport is not an HTTPTransport.
+func (c *Client) Sync(ctx context.Context) {
+	if t, ok := c.transport.(*HTTPTransport); ok {
+		t.Sync(ctx)
+	}
+}
+
+// StartSync runs Sync in the background on the given interval until the
+// returned stop function is called. Returns a no-op stop function if
+// the Client's Transport is not an HTTPTransport.
+func (c *Client) StartSync(interval time.Duration) (stop func()) {
+	if t, ok := c.transport.(*HTTPTransport); ok {
+		return t.StartSync(interval)
+	}
+	return func() {}
+}
@@ -8,13 +8,11 @@
 package crowny
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -220,42 +218,92 @@ func (h CtpHeader) OverallState() TritValue {
 
 // Client is the main Crowny SDK client
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
-	ctp        CtpHeader
-	taskCount  int64
-	history    []TritResult
-	mu         sync.Mutex
-}
-
-// NewClient creates a new Crowny client
-func NewClient(baseURL string) *Client {
-	return &Client{
-		baseURL: strings.TrimRight(baseURL, "/"),
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		ctp:     CtpSuccess(),
-		history: make([]TritResult, 0),
+	transport Transport
+	ctp       CtpHeader
+	taskCount int64
+	history   []TritResult
+	mu        sync.Mutex
+
+	maxFrameBytes int
+
+	logger    Logger
+	hooksMu   sync.RWMutex
+	hooks     []Hook
+	hookQueue chan HookEvent
+	hookOnce  sync.Once
+
+	checksMu    sync.RWMutex
+	checks      map[string]HealthCheckFunc
+	healthCtp   atomic.Value
+	healthMonMu sync.Mutex
+	healthStop  chan struct{}
+}
+
+// NewClient creates a new Crowny client backed by a single server,
+// communicating over HTTP unless overridden with WithTransport.
+func NewClient(baseURL string, opts ...Option) *Client {
+	return NewClusterClient([]string{baseURL}, opts...)
+}
+
+// NewClusterClient creates a new Crowny client backed by an ordered list
+// of server endpoints, following the same pinned-endpoint failover model
+// as etcd's httpClusterClient: the default HTTPTransport attempts the
+// pinned endpoint first and rotates to the next one on transport errors
+// or 5xx responses. Pass WithTransport to use JSON-RPC or gRPC instead.
+func NewClusterClient(endpoints []string, opts ...Option) *Client {
+	c := &Client{
+		transport: newHTTPTransport(endpoints),
+		ctp:       CtpSuccess(),
+		history:   make([]TritResult, 0),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
-// WithTimeout sets the HTTP timeout
+// WithTimeout sets the HTTP timeout. A no-op if the Client's Transport
+// is not an HTTPTransport.
 func (c *Client) WithTimeout(d time.Duration) *Client {
-	c.httpClient.Timeout = d
+	if t, ok := c.transport.(*HTTPTransport); ok {
+		t.httpClient.Timeout = d
+	}
 	return c
 }
 
 // WithCTP sets the CTP header
 func (c *Client) WithCTP(ctp CtpHeader) *Client {
-	c.ctp = ctp
+	c.setCtp(ctp)
 	return c
 }
 
+// ctp is read and written by concurrent SubmitContext calls (including
+// those ConsensusCallContext spawns) and by watchLoop, so every access goes through
+// c.mu rather than touching the field directly.
+func (c *Client) ctpSnapshot() CtpHeader {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ctp
+}
+
+func (c *Client) setCtp(ctp CtpHeader) {
+	c.mu.Lock()
+	c.ctp = ctp
+	c.mu.Unlock()
+}
+
 // ── 핵심: Submit ──
 
 // Submit sends a task to the Crowny server via CAR
 func (c *Client) Submit(taskType, subject, payload string, params map[string]string) (TritResult, error) {
+	return c.SubmitContext(context.Background(), taskType, subject, payload, params)
+}
+
+// SubmitContext sends a task through the Client's Transport, honoring
+// ctx cancellation and deadline. If ctx is canceled or its deadline
+// expires before a response is received, it returns a TritResult with
+// State: T and Data set to ctx.Err().
+func (c *Client) SubmitContext(ctx context.Context, taskType, subject, payload string, params map[string]string) (TritResult, error) {
 	c.mu.Lock()
 	c.taskCount++
 	taskID := c.taskCount
@@ -263,56 +311,34 @@ func (c *Client) Submit(taskType, subject, payload string, params map[string]str
 
 	start := time.Now()
 
-	body := map[string]interface{}{
-		"type":    taskType,
-		"subject": subject,
-		"payload": payload,
-		"params":  params,
-	}
+	c.logDebug("submit", "task_type", taskType, "task_id", taskID, "subject", subject)
 
-	data, err := json.Marshal(body)
-	if err != nil {
-		result := TritResult{State: T, Data: err.Error(), ElapsedMs: elapsed(start), TaskID: taskID}
-		c.addHistory(result)
-		return result, err
+	req := TaskRequest{Type: taskType, Subject: subject, Payload: payload, Params: params}
+	resp, ctp, err := c.transport.Do(ctx, req, c.ctpSnapshot())
+	if ctp != (CtpHeader{}) {
+		c.setCtp(ctp)
 	}
-
-	req, err := http.NewRequest("POST", c.baseURL+"/run", bytes.NewReader(data))
-	if err != nil {
-		result := TritResult{State: T, Data: err.Error(), ElapsedMs: elapsed(start), TaskID: taskID}
-		c.addHistory(result)
-		return result, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Crowny-Trit", c.ctp.String())
-	req.Header.Set("X-Crowny-Version", "1.0")
-
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		if ctx.Err() != nil {
+			result := TritResult{State: T, Data: ctx.Err(), ElapsedMs: elapsed(start), TaskID: taskID}
+			c.logWarn("submit canceled", "task_type", taskType, "task_id", taskID, "err", ctx.Err())
+			c.addHistory(result, taskType)
+			return result, ctx.Err()
+		}
 		result := TritResult{State: T, Data: err.Error(), ElapsedMs: elapsed(start), TaskID: taskID}
-		c.addHistory(result)
+		c.logError("submit failed", "task_type", taskType, "task_id", taskID, "err", err)
+		c.addHistory(result, taskType)
 		return result, err
 	}
-	defer resp.Body.Close()
-
-	// Parse CTP response header
-	if ctpStr := resp.Header.Get("X-Crowny-Trit"); ctpStr != "" {
-		c.ctp = ParseCtpHeader(ctpStr)
-	}
-
-	respBody, _ := io.ReadAll(resp.Body)
-	var respData map[string]interface{}
-	json.Unmarshal(respBody, &respData)
 
-	state := parseTritFromResponse(respData)
 	result := TritResult{
-		State:     state,
-		Data:      respData,
+		State:     resp.State,
+		Data:      resp.Data,
 		ElapsedMs: elapsed(start),
 		TaskID:    taskID,
 	}
-	c.addHistory(result)
+	c.logInfo("submit complete", "task_type", taskType, "task_id", taskID, "state", result.State, "elapsed_ms", result.ElapsedMs)
+	c.addHistory(result, taskType)
 	return result, nil
 }
 
@@ -320,22 +346,42 @@ func (c *Client) Submit(taskType, subject, payload string, params map[string]str
 
 // Run executes 한선어 source code
 func (c *Client) Run(source string) (TritResult, error) {
-	return c.Submit("execute", "sdk-go", source, nil)
+	return c.RunContext(context.Background(), source)
+}
+
+// RunContext executes 한선어 source code, honoring ctx cancellation.
+func (c *Client) RunContext(ctx context.Context, source string) (TritResult, error) {
+	return c.SubmitContext(ctx, "execute", "sdk-go", source, nil)
 }
 
 // Compile compiles to WASM
 func (c *Client) Compile(source string) (TritResult, error) {
-	return c.Submit("compile", "sdk-go", source, nil)
+	return c.CompileContext(context.Background(), source)
+}
+
+// CompileContext compiles to WASM, honoring ctx cancellation.
+func (c *Client) CompileContext(ctx context.Context, source string) (TritResult, error) {
+	return c.SubmitContext(ctx, "compile", "sdk-go", source, nil)
 }
 
 // Ask calls an LLM
 func (c *Client) Ask(prompt string) (TritResult, error) {
-	return c.Submit("llm", "claude", prompt, nil)
+	return c.AskContext(context.Background(), prompt)
+}
+
+// AskContext calls an LLM, honoring ctx cancellation.
+func (c *Client) AskContext(ctx context.Context, prompt string) (TritResult, error) {
+	return c.SubmitContext(ctx, "llm", "claude", prompt, nil)
 }
 
 // AskModel calls a specific LLM model
 func (c *Client) AskModel(prompt, model string) (TritResult, error) {
-	return c.Submit("llm", model, prompt, nil)
+	return c.AskModelContext(context.Background(), prompt, model)
+}
+
+// AskModelContext calls a specific LLM model, honoring ctx cancellation.
+func (c *Client) AskModelContext(ctx context.Context, prompt, model string) (TritResult, error) {
+	return c.SubmitContext(ctx, "llm", model, prompt, nil)
 }
 
 // ── 합의 ──
@@ -357,26 +403,84 @@ type ModelResult struct {
 
 // ConsensusCall performs multi-model consensus
 func (c *Client) ConsensusCall(prompt string, models []string) (ConsensusResult, error) {
+	return c.ConsensusCallContext(context.Background(), prompt, models)
+}
+
+// ConsensusCallContext performs multi-model consensus, honoring ctx
+// cancellation. It short-circuits as soon as a P or T majority is
+// mathematically guaranteed (more than half the models have already
+// agreed on the same non-O state), canceling the remaining in-flight
+// AskModelContext calls instead of waiting on the full wg.Wait(). If
+// ctx is canceled before consensus is reached, the outstanding calls
+// are canceled and the returned ConsensusResult reflects whatever
+// models answered in time, with unanswered slots set to
+// TritResult{State: O, Data: ctx.Err()}.
+func (c *Client) ConsensusCallContext(ctx context.Context, prompt string, models []string) (ConsensusResult, error) {
 	start := time.Now()
 
 	if len(models) == 0 {
 		models = []string{"claude", "gpt4", "gemini"}
 	}
 
+	callCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	majority := len(models)/2 + 1
+
+	var mu sync.Mutex
 	var wg sync.WaitGroup
 	results := make([]ModelResult, len(models))
-	errors := make([]error, len(models))
+	answered := make([]bool, len(models))
+	pCount, tCount := 0, 0
 
 	for i, model := range models {
 		wg.Add(1)
 		go func(idx int, m string) {
 			defer wg.Done()
-			r, err := c.AskModel(prompt, m)
+			r, err := c.AskModelContext(callCtx, prompt, m)
+
+			mu.Lock()
+			if err != nil && callCtx.Err() != nil {
+				// Canceled before answering: left unanswered and filled
+				// in with State O below, so it does not count as a T vote.
+				mu.Unlock()
+				return
+			}
 			results[idx] = ModelResult{Model: m, Result: r}
-			errors[idx] = err
+			answered[idx] = true
+			switch r.State {
+			case P:
+				pCount++
+			case T:
+				tCount++
+			}
+			if pCount >= majority || tCount >= majority {
+				cancel()
+			}
+			mu.Unlock()
 		}(i, model)
 	}
-	wg.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		cancel()
+		<-done
+	}
+
+	mu.Lock()
+	for i, m := range models {
+		if !answered[i] {
+			results[i] = ModelResult{Model: m, Result: TritResult{State: O, Data: ctx.Err()}}
+		}
+	}
+	mu.Unlock()
 
 	trits := make([]TritValue, len(results))
 	for i, r := range results {
@@ -389,23 +493,58 @@ func (c *Client) ConsensusCall(prompt string, models []string) (ConsensusResult,
 		Consensus: con,
 		Models:    results,
 		Trits:     trits,
-		CTP:       CtpHeader{Trits: [9]TritValue{con, trits[0], trits[1], trits[2], O, O, O, O, O}},
+		CTP:       ctpFromTrits(con, trits),
 		ElapsedMs: elapsed(start),
-	}, nil
+	}, ctx.Err()
+}
+
+// ctpFromTrits packs an overall consensus state and the individual model
+// trits that produced it into a 9-trit CtpHeader: slot 0 holds con, and
+// slots 1-8 hold as many of trits as fit, left-to-right, padded with O.
+// Unlike indexing trits[0:3] directly, this never panics when len(models)
+// is not exactly 3, and it doesn't silently drop trits beyond the 3rd
+// when there are more models than that — it just keeps packing until the
+// header runs out of room.
+func ctpFromTrits(con TritValue, trits []TritValue) CtpHeader {
+	h := NewCtpHeader()
+	h.Trits[0] = con
+	for i, t := range trits {
+		if i+1 >= len(h.Trits) {
+			break
+		}
+		h.Trits[i+1] = t
+	}
+	return h
 }
 
 // ── 상태 ──
 
 // Ping checks server connectivity
 func (c *Client) Ping() (TritResult, error) {
+	return c.PingContext(context.Background())
+}
+
+// PingContext checks connectivity through the Client's Transport,
+// honoring ctx cancellation and deadline. Returns an error if the
+// Transport does not implement Pinger.
+func (c *Client) PingContext(ctx context.Context) (TritResult, error) {
 	start := time.Now()
-	resp, err := c.httpClient.Get(c.baseURL + "/")
+
+	pinger, ok := c.transport.(Pinger)
+	if !ok {
+		err := fmt.Errorf("crowny: transport %T does not support Ping", c.transport)
+		return TritResult{State: T, Data: err.Error(), ElapsedMs: elapsed(start)}, err
+	}
+
+	state, err := pinger.Ping(ctx)
 	if err != nil {
+		if ctx.Err() != nil {
+			return TritResult{State: T, Data: ctx.Err(), ElapsedMs: elapsed(start)}, ctx.Err()
+		}
 		return TritResult{State: T, Data: "unreachable", ElapsedMs: elapsed(start)}, err
 	}
-	defer resp.Body.Close()
 
-	return TritResult{State: P, Data: "ok", ElapsedMs: elapsed(start)}, nil
+	return TritResult{State: state, Data: "ok", ElapsedMs: elapsed(start)}, nil
 }
 
 // History returns all past results
@@ -437,13 +576,15 @@ func (c *Client) Stats() (total, p, o, t int) {
 
 // ── 내부 ──
 
-func (c *Client) addHistory(r TritResult) {
+func (c *Client) addHistory(r TritResult, taskType string) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	c.history = append(c.history, r)
 	if len(c.history) > 1000 {
 		c.history = c.history[len(c.history)-1000:]
 	}
+	c.mu.Unlock()
+
+	c.dispatchHooks(HookEvent{TritResult: r, TaskType: taskType, CTP: c.ctpSnapshot()})
 }
 
 func elapsed(start time.Time) int64 {
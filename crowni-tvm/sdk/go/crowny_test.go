@@ -1,7 +1,21 @@
 package crowny
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/cenkalti/rpc2/jsonrpc"
+	"github.com/gorilla/websocket"
 )
 
 func TestTritValues(t *testing.T) {
@@ -142,6 +156,309 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClusterClient(t *testing.T) {
+	c := NewClusterClient([]string{"http://host-a:7293", "http://host-b:7293/"})
+	eps := c.Endpoints()
+	if len(eps) != 2 {
+		t.Fatalf("expected 2 endpoints, got %d", len(eps))
+	}
+	if eps[1] != "http://host-b:7293" {
+		t.Errorf("expected trailing slash trimmed, got %s", eps[1])
+	}
+
+	health := c.EndpointHealth()
+	for _, ep := range eps {
+		if health[ep] != O {
+			t.Errorf("new endpoint %s should start untested (O), got %s", ep, health[ep])
+		}
+	}
+}
+
+func TestSingleURLActsAsOneEndpointCluster(t *testing.T) {
+	c := NewClient("http://localhost:7293")
+	eps := c.Endpoints()
+	if len(eps) != 1 || eps[0] != "http://localhost:7293" {
+		t.Errorf("NewClient should produce a 1-element cluster, got %v", eps)
+	}
+}
+
+func TestWithEndpointsResetsHealth(t *testing.T) {
+	c := NewClusterClient([]string{"http://host-a:7293"})
+	c.transport.(*HTTPTransport).setHealth("http://host-a:7293", T)
+
+	c.WithEndpoints([]string{"http://host-c:7293"})
+	health := c.EndpointHealth()
+	if len(health) != 1 || health["http://host-c:7293"] != O {
+		t.Errorf("WithEndpoints should reset health for the new endpoint set, got %v", health)
+	}
+}
+
+func TestToWebsocketURL(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"http://localhost:7293", "ws://localhost:7293"},
+		{"https://crowny.example.com", "wss://crowny.example.com"},
+	}
+	for _, tt := range tests {
+		got, err := toWebsocketURL(tt.in)
+		if err != nil {
+			t.Fatalf("toWebsocketURL(%q) error: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("toWebsocketURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTritEventIsTerminal(t *testing.T) {
+	if !(TritEvent{State: P}).IsTerminal() {
+		t.Error("P event should be terminal")
+	}
+	if !(TritEvent{State: T}).IsTerminal() {
+		t.Error("T event should be terminal")
+	}
+	if (TritEvent{State: O}).IsTerminal() {
+		t.Error("O event should not be terminal")
+	}
+}
+
+func TestMaxFrameBytesDefault(t *testing.T) {
+	c := NewClient("http://localhost:7293")
+	if c.maxFrameBytesOrDefault() != defaultMaxFrameBytes {
+		t.Errorf("expected default max frame bytes %d, got %d", defaultMaxFrameBytes, c.maxFrameBytesOrDefault())
+	}
+	c.WithMaxFrameBytes(2 << 20)
+	if c.maxFrameBytesOrDefault() != 2<<20 {
+		t.Errorf("expected overridden max frame bytes, got %d", c.maxFrameBytesOrDefault())
+	}
+}
+
+// TestWatchReconnectsAndResumesFromLastSeq drives Client.Watch's raw
+// websocket fallback against a fake server: the first connection sends
+// one event then drops without a terminal state, and the test asserts
+// watchLoop reconnects and that the reconnect's since_seq resumes from
+// the last event it saw rather than from 0.
+func TestWatchReconnectsAndResumesFromLastSeq(t *testing.T) {
+	var connCount int32
+	resumedSinceSeq := make(chan int64, 1)
+	upgrader := websocket.Upgrader{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		n := atomic.AddInt32(&connCount, 1)
+		sinceSeq, _ := strconv.ParseInt(r.URL.Query().Get("since_seq"), 10, 64)
+
+		if n == 1 {
+			conn.WriteJSON(TritEvent{State: O, TaskID: 1, Seq: 1})
+			return // drop the connection without a terminal event
+		}
+
+		resumedSinceSeq <- sinceSeq
+		conn.WriteJSON(TritEvent{State: P, TaskID: 1, Seq: 2})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := c.Watch(ctx, 1)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	var events []TritEvent
+	for ev := range ch {
+		events = append(events, ev)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events across the reconnect, got %d: %+v", len(events), events)
+	}
+	if events[0].Seq != 1 || events[1].Seq != 2 {
+		t.Errorf("expected seq 1 then 2, got %d then %d", events[0].Seq, events[1].Seq)
+	}
+	if !events[1].IsTerminal() {
+		t.Error("second event should be terminal (State P) and close the channel")
+	}
+
+	select {
+	case got := <-resumedSinceSeq:
+		if got != 1 {
+			t.Errorf("expected reconnect to resume since_seq=1, got %d", got)
+		}
+	default:
+		t.Fatal("server never observed a reconnect")
+	}
+}
+
+type fakeTransport struct {
+	state TritValue
+}
+
+func (f *fakeTransport) Do(ctx context.Context, req TaskRequest, ctp CtpHeader) (TaskResponse, CtpHeader, error) {
+	return TaskResponse{State: f.state, Data: req.Payload}, CtpHeader{}, nil
+}
+
+func (f *fakeTransport) Ping(ctx context.Context) (TritValue, error) {
+	return f.state, nil
+}
+
+func TestWithTransportOverridesDefault(t *testing.T) {
+	c := NewClient("http://localhost:7293", WithTransport(&fakeTransport{state: P}))
+
+	r, err := c.Run("넣어 42\n종료")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.State != P {
+		t.Errorf("expected P from fakeTransport, got %s", r.State)
+	}
+	if r.Data != "넣어 42\n종료" {
+		t.Errorf("expected payload to round-trip through fakeTransport, got %v", r.Data)
+	}
+
+	// Endpoints()/EndpointHealth() are HTTPTransport-only and should be
+	// harmless no-ops for a non-HTTP transport.
+	if eps := c.Endpoints(); eps != nil {
+		t.Errorf("expected nil Endpoints() for non-HTTP transport, got %v", eps)
+	}
+}
+
+func TestStatsHookFire(t *testing.T) {
+	c := NewClient("http://localhost:7293", WithTransport(&fakeTransport{state: P}))
+	hook := NewStatsHook()
+	c.AddHook(hook)
+
+	if _, err := c.Run("넣어 42\n종료"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		p, _, _ := hook.Totals()
+		if p == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	p, o, tr := hook.Totals()
+	if p != 1 || o != 0 || tr != 0 {
+		t.Errorf("expected 1 P total after one Run, got p=%d o=%d t=%d", p, o, tr)
+	}
+	if lat := hook.LatencyMs("execute"); len(lat) != 1 {
+		t.Errorf("expected 1 latency sample for execute, got %v", lat)
+	}
+}
+
+func TestJSONFileHookFire(t *testing.T) {
+	f, err := os.CreateTemp("", "crowny-audit-*.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	hook, err := NewJSONFileHook(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer hook.Close()
+
+	hook.Fire(HookEvent{
+		TritResult: TritResult{State: P, TaskID: 7, ElapsedMs: 12},
+		TaskType:   "execute",
+		CTP:        CtpSuccess(),
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rec jsonFileRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		t.Fatalf("expected valid JSON line, got %q: %v", data, err)
+	}
+	if rec.TaskID != 7 || rec.TaskType != "execute" || rec.State != P {
+		t.Errorf("unexpected audit record: %+v", rec)
+	}
+}
+
+func TestPingContextCanceled(t *testing.T) {
+	c := NewClient("http://localhost:7293")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r, err := c.PingContext(ctx)
+	if err == nil {
+		t.Error("expected error for canceled context")
+	}
+	if !r.IsFailed() {
+		t.Error("canceled ping should report a failed result")
+	}
+}
+
+func TestConsensusCallContextCanceled(t *testing.T) {
+	c := NewClient("http://localhost:7293")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res, err := c.ConsensusCallContext(ctx, "ping", []string{"claude", "gpt4"})
+	if err == nil {
+		t.Error("expected error for canceled context")
+	}
+	if len(res.Models) != 2 {
+		t.Errorf("expected 2 model results, got %d", len(res.Models))
+	}
+}
+
+func TestHealthAllChecksHealthy(t *testing.T) {
+	c := NewClient("http://localhost:7293", WithTransport(&fakeTransport{state: P}))
+
+	report := c.Health(context.Background())
+	if report.Overall != P {
+		t.Errorf("expected overall P when every check is P, got %s", report.Overall)
+	}
+	if len(report.Checks) != 4 {
+		t.Errorf("expected 4 built-in checks, got %d: %+v", len(report.Checks), report.Checks)
+	}
+}
+
+func TestHealthRegisterCheckOverridesBuiltin(t *testing.T) {
+	c := NewClient("http://localhost:7293", WithTransport(&fakeTransport{state: P}))
+	c.RegisterCheck("server_ping", func(ctx context.Context) (TritValue, error) {
+		return T, fmt.Errorf("forced failure")
+	})
+
+	report := c.Health(context.Background())
+	if report.Overall != T {
+		t.Errorf("expected overall T when server_ping is overridden to fail, got %s", report.Overall)
+	}
+}
+
+func TestHealthHandlerReflectsLastCheck(t *testing.T) {
+	c := NewClient("http://localhost:7293", WithTransport(&fakeTransport{state: P}))
+	c.Health(context.Background())
+
+	handler := HealthHandler(c)
+
+	for _, path := range []string{"/readyz", "/livez"} {
+		req := httptest.NewRequest("GET", path, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", path, rec.Code)
+		}
+	}
+}
+
 func TestTritResultMethods(t *testing.T) {
 	r := TritResult{State: P, Data: "ok", ElapsedMs: 10, TaskID: 1}
 	if !r.IsSuccess() {
@@ -161,3 +478,91 @@ func TestTritResultMethods(t *testing.T) {
 		t.Error("T result should be failed")
 	}
 }
+
+// TestJSONRPCTransportWatchTwice exercises Watch against a fake
+// JSON-RPC server, pushing a Crowny.Event notification for two
+// different tasks in turn. The second Watch call must not panic with
+// rpc2's "multiple registrations" error, and each task's events must
+// only reach its own channel.
+func TestJSONRPCTransportWatchTwice(t *testing.T) {
+	srv := rpc2.NewServer()
+	clientCh := make(chan *rpc2.Client, 1)
+	srv.OnConnect(func(c *rpc2.Client) {
+		clientCh <- c
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer lis.Close()
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go srv.ServeCodec(jsonrpc.NewJSONCodec(conn))
+		}
+	}()
+
+	transport, err := NewJSONRPCTransport(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("NewJSONRPCTransport: %v", err)
+	}
+	defer transport.Close()
+
+	var rpcClient *rpc2.Client
+	select {
+	case rpcClient = <-clientCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server never observed a connection")
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	ch1, err := transport.Watch(ctx1, 1)
+	if err != nil {
+		t.Fatalf("first Watch: %v", err)
+	}
+	rpcClient.Notify("Crowny.Event", &TritEvent{TaskID: 1, Seq: 1, State: P})
+
+	select {
+	case ev := <-ch1:
+		if ev.TaskID != 1 || ev.Seq != 1 {
+			t.Errorf("unexpected event on first watch: %+v", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for first watch's event")
+	}
+
+	// State P is terminal, so the transport should have unregistered
+	// and closed ch1 on its own.
+	select {
+	case _, ok := <-ch1:
+		if ok {
+			t.Error("expected ch1 to be closed after a terminal event")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ch1 never closed after a terminal event")
+	}
+
+	// A second Watch call must not panic registering Crowny.Event again.
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	ch2, err := transport.Watch(ctx2, 2)
+	if err != nil {
+		t.Fatalf("second Watch: %v", err)
+	}
+	rpcClient.Notify("Crowny.Event", &TritEvent{TaskID: 2, Seq: 1, State: P})
+
+	select {
+	case ev := <-ch2:
+		if ev.TaskID != 2 || ev.Seq != 1 {
+			t.Errorf("unexpected event on second watch: %+v", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for second watch's event")
+	}
+}
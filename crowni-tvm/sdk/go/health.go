@@ -0,0 +1,258 @@
+package crowny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ═══════════════════════════════════════════════
+// Health / readiness
+// ═══════════════════════════════════════════════
+
+const healthCanaryPrompt = "ping"
+const healthSmokeSource = "넣어 1\n종료"
+
+var defaultConsensusModels = []string{"claude", "gpt4", "gemini"}
+
+// HealthCheckFunc is a single named health probe.
+type HealthCheckFunc func(ctx context.Context) (TritValue, error)
+
+// Check is the result of one HealthCheckFunc.
+type Check struct {
+	Name      string
+	State     TritValue
+	LatencyMs int64
+	Err       string
+	CheckedAt time.Time
+}
+
+// HealthReport is the result of running every registered check.
+type HealthReport struct {
+	Overall TritValue
+	Checks  []Check
+}
+
+// RegisterCheck adds a custom named probe to Health/StartHealthMonitor,
+// alongside the built-in server_ping, llm_reachable, compile_smoke, and
+// consensus_quorum checks. Registering under an existing name replaces
+// it, including a built-in of the same name.
+func (c *Client) RegisterCheck(name string, fn func(ctx context.Context) (TritValue, error)) {
+	c.checksMu.Lock()
+	if c.checks == nil {
+		c.checks = make(map[string]HealthCheckFunc)
+	}
+	c.checks[name] = fn
+	c.checksMu.Unlock()
+}
+
+// Health runs every built-in and registered check concurrently and
+// returns the aggregate report. It also refreshes the CtpHeader
+// returned by HealthCtp, so a concurrent HealthHandler request always
+// reflects the most recent Health or StartHealthMonitor tick.
+func (c *Client) Health(ctx context.Context) HealthReport {
+	all := c.builtinChecks()
+	c.checksMu.RLock()
+	for name, fn := range c.checks {
+		all[name] = fn
+	}
+	c.checksMu.RUnlock()
+
+	type named struct {
+		name string
+		fn   HealthCheckFunc
+	}
+	list := make([]named, 0, len(all))
+	for name, fn := range all {
+		list = append(list, named{name, fn})
+	}
+
+	checks := make([]Check, len(list))
+	var wg sync.WaitGroup
+	for i, n := range list {
+		wg.Add(1)
+		go func(idx int, name string, fn HealthCheckFunc) {
+			defer wg.Done()
+			start := time.Now()
+			state, err := fn(ctx)
+			chk := Check{Name: name, State: state, LatencyMs: elapsed(start), CheckedAt: start}
+			if err != nil {
+				chk.Err = err.Error()
+			}
+			checks[idx] = chk
+		}(i, n.name, n.fn)
+	}
+	wg.Wait()
+
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+
+	overall := overallFromChecks(checks)
+	c.updateHealthCtp(checks, overall)
+
+	return HealthReport{Overall: overall, Checks: checks}
+}
+
+func overallFromChecks(checks []Check) TritValue {
+	hasT, allP := false, true
+	for _, chk := range checks {
+		if chk.State == T {
+			hasT = true
+		}
+		if chk.State != P {
+			allP = false
+		}
+	}
+	if hasT {
+		return T
+	}
+	if allP {
+		return P
+	}
+	return O
+}
+
+// updateHealthCtp stores the latest health state as a CtpHeader whose
+// first three trits encode overall/liveness/readiness, directly usable
+// by a Kubernetes /livez and /readyz handler (see HealthHandler).
+// Liveness tracks the server_ping check specifically; readiness
+// reflects the combined state of every check.
+func (c *Client) updateHealthCtp(checks []Check, overall TritValue) {
+	liveness := O
+	for _, chk := range checks {
+		if chk.Name == "server_ping" {
+			liveness = chk.State
+		}
+	}
+	c.healthCtp.Store(CtpHeader{Trits: [9]TritValue{overall, liveness, overall, O, O, O, O, O, O}})
+}
+
+// HealthCtp returns the CtpHeader last produced by Health or
+// StartHealthMonitor. Before the first check has run, it returns
+// NewCtpHeader's all-O default.
+func (c *Client) HealthCtp() CtpHeader {
+	if v := c.healthCtp.Load(); v != nil {
+		return v.(CtpHeader)
+	}
+	return NewCtpHeader()
+}
+
+// StartHealthMonitor runs Health in the background on the given
+// interval until the returned stop function is called. Calling
+// StartHealthMonitor again first stops any previously running loop.
+func (c *Client) StartHealthMonitor(interval time.Duration) (stop func()) {
+	c.healthMonMu.Lock()
+	if c.healthStop != nil {
+		close(c.healthStop)
+	}
+	stopCh := make(chan struct{})
+	c.healthStop = stopCh
+	c.healthMonMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				c.Health(ctx)
+				cancel()
+			}
+		}
+	}()
+
+	return func() {
+		c.healthMonMu.Lock()
+		defer c.healthMonMu.Unlock()
+		if c.healthStop == stopCh {
+			close(stopCh)
+			c.healthStop = nil
+		}
+	}
+}
+
+// ── 내장 점검 ──
+
+func (c *Client) builtinChecks() map[string]HealthCheckFunc {
+	return map[string]HealthCheckFunc{
+		"server_ping":      c.checkServerPing,
+		"llm_reachable":    c.checkLLMReachable,
+		"compile_smoke":    c.checkCompileSmoke,
+		"consensus_quorum": c.checkConsensusQuorum,
+	}
+}
+
+func (c *Client) checkServerPing(ctx context.Context) (TritValue, error) {
+	r, err := c.PingContext(ctx)
+	return r.State, err
+}
+
+func (c *Client) checkLLMReachable(ctx context.Context) (TritValue, error) {
+	r, err := c.AskContext(ctx, healthCanaryPrompt)
+	return r.State, err
+}
+
+func (c *Client) checkCompileSmoke(ctx context.Context) (TritValue, error) {
+	r, err := c.CompileContext(ctx, healthSmokeSource)
+	return r.State, err
+}
+
+// checkConsensusQuorum verifies at least ceil(n/2) of the default
+// consensus models respond (regardless of their individual P/O/T
+// verdict) within ctx's deadline.
+func (c *Client) checkConsensusQuorum(ctx context.Context) (TritValue, error) {
+	models := defaultConsensusModels
+	quorum := (len(models) + 1) / 2
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	responded := 0
+
+	for _, model := range models {
+		wg.Add(1)
+		go func(m string) {
+			defer wg.Done()
+			_, err := c.AskModelContext(ctx, healthCanaryPrompt, m)
+			if err == nil {
+				mu.Lock()
+				responded++
+				mu.Unlock()
+			}
+		}(model)
+	}
+	wg.Wait()
+
+	if responded >= quorum {
+		return P, nil
+	}
+	return T, fmt.Errorf("crowny: only %d/%d consensus models responded, need %d", responded, len(models), quorum)
+}
+
+// HealthHandler returns an http.Handler suitable for both a Kubernetes
+// /livez and /readyz probe: it reports liveness for any path ending in
+// "livez" and readiness otherwise, reading the CtpHeader last produced
+// by Health or StartHealthMonitor. It responds 200 when the relevant
+// trit is P and 503 otherwise.
+func HealthHandler(c *Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := c.HealthCtp()
+		trit := h.Trits[2] // readiness
+		if strings.HasSuffix(r.URL.Path, "livez") {
+			trit = h.Trits[1] // liveness
+		}
+
+		status := http.StatusOK
+		if trit != P {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprintln(w, trit.String())
+	})
+}
@@ -0,0 +1,20 @@
+//go:build windows || plan9 || js
+
+package crowny
+
+import "fmt"
+
+// SyslogHook is unavailable on this platform; log/syslog has no
+// implementation for windows, plan9, or js.
+type SyslogHook struct{}
+
+// NewSyslogHook always returns an error on this platform.
+func NewSyslogHook(network, addr, tag string) (*SyslogHook, error) {
+	return nil, fmt.Errorf("crowny: SyslogHook is not supported on this platform")
+}
+
+// Fire implements Hook as a no-op.
+func (h *SyslogHook) Fire(event HookEvent) {}
+
+// Close implements io.Closer as a no-op.
+func (h *SyslogHook) Close() error { return nil }
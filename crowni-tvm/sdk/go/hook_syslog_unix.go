@@ -0,0 +1,52 @@
+//go:build !windows && !plan9 && !js
+
+package crowny
+
+import (
+	"fmt"
+	"log/syslog"
+	"sync"
+)
+
+// SyslogHook emits one line per task to a syslog server: P/O/T state
+// and elapsed_ms, tagged for the caller's log aggregation.
+type SyslogHook struct {
+	mu     sync.Mutex
+	writer *syslog.Writer
+}
+
+// NewSyslogHook dials network/addr (e.g. "udp", "log.example.com:514";
+// network "" dials the local syslog daemon) and returns a ready-to-use
+// SyslogHook tagged with tag.
+func NewSyslogHook(network, addr, tag string) (*SyslogHook, error) {
+	w, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogHook{writer: w}, nil
+}
+
+// Fire implements Hook.
+func (h *SyslogHook) Fire(event HookEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	line := fmt.Sprintf("task_id=%d task_type=%s state=%s elapsed_ms=%d",
+		event.TaskID, event.TaskType, event.State, event.ElapsedMs)
+
+	switch event.State {
+	case T:
+		h.writer.Err(line)
+	case O:
+		h.writer.Warning(line)
+	default:
+		h.writer.Info(line)
+	}
+}
+
+// Close closes the underlying syslog connection.
+func (h *SyslogHook) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.writer.Close()
+}
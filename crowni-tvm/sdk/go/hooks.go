@@ -0,0 +1,224 @@
+package crowny
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// ═══════════════════════════════════════════════
+// Observability: Logger and Hook
+// ═══════════════════════════════════════════════
+
+const (
+	defaultHookWorkers   = 4
+	defaultHookQueueSize = 256
+)
+
+// Logger is the minimal structured-logging interface Client logs
+// through. Each method takes a message followed by alternating
+// key/value pairs, mirroring logrus's Entry.WithFields usage.
+type Logger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+}
+
+// HookEvent is what a Hook receives for every completed task: the
+// TritResult plus the context needed for audit/metrics sinks that
+// TritResult alone doesn't carry.
+type HookEvent struct {
+	TritResult
+	TaskType string
+	CTP      CtpHeader
+}
+
+// Hook is modeled on logrus hooks: Fire is called for every TritResult
+// after addHistory. Hooks run in a bounded worker pool (see AddHook),
+// so a slow hook cannot stall Submit, but a sustained backlog will
+// cause events to be dropped rather than buffered without limit.
+type Hook interface {
+	Fire(event HookEvent)
+}
+
+// WithLogger sets the Logger used for Client's own structured log
+// lines (submit/cancel/failure). Hooks are independent of Logger and
+// are configured separately via AddHook.
+func (c *Client) WithLogger(l Logger) *Client {
+	c.logger = l
+	return c
+}
+
+// AddHook registers h to receive every TritResult produced by Submit.
+// The first call to AddHook starts a bounded pool of worker goroutines
+// that drain a queue of HookEvents; if the queue is full, the event is
+// dropped and logged via Warn rather than blocking the caller.
+func (c *Client) AddHook(h Hook) {
+	c.hookOnce.Do(c.startHookWorkers)
+
+	c.hooksMu.Lock()
+	c.hooks = append(c.hooks, h)
+	c.hooksMu.Unlock()
+}
+
+func (c *Client) startHookWorkers() {
+	c.hooksMu.Lock()
+	c.hookQueue = make(chan HookEvent, defaultHookQueueSize)
+	c.hooksMu.Unlock()
+
+	for i := 0; i < defaultHookWorkers; i++ {
+		go c.hookWorker()
+	}
+}
+
+func (c *Client) hookWorker() {
+	for event := range c.hookQueue {
+		c.hooksMu.RLock()
+		hooks := c.hooks
+		c.hooksMu.RUnlock()
+
+		for _, h := range hooks {
+			h.Fire(event)
+		}
+	}
+}
+
+func (c *Client) dispatchHooks(event HookEvent) {
+	c.hooksMu.RLock()
+	queue := c.hookQueue
+	c.hooksMu.RUnlock()
+
+	if queue == nil {
+		return
+	}
+	select {
+	case queue <- event:
+	default:
+		c.logWarn("hook queue full, dropping event", "task_id", event.TaskID, "task_type", event.TaskType)
+	}
+}
+
+func (c *Client) logDebug(msg string, kv ...interface{}) {
+	if c.logger != nil {
+		c.logger.Debug(msg, kv...)
+	}
+}
+
+func (c *Client) logInfo(msg string, kv ...interface{}) {
+	if c.logger != nil {
+		c.logger.Info(msg, kv...)
+	}
+}
+
+func (c *Client) logWarn(msg string, kv ...interface{}) {
+	if c.logger != nil {
+		c.logger.Warn(msg, kv...)
+	}
+}
+
+func (c *Client) logError(msg string, kv ...interface{}) {
+	if c.logger != nil {
+		c.logger.Error(msg, kv...)
+	}
+}
+
+// ═══════════════════════════════════════════════
+// Built-in hooks
+// ═══════════════════════════════════════════════
+
+// StatsHook tallies P/O/T totals and per-taskType latency across every
+// task the Client submits.
+type StatsHook struct {
+	mu      sync.Mutex
+	pTotal  int64
+	oTotal  int64
+	tTotal  int64
+	latency map[string][]int64 // taskType -> elapsed_ms samples
+}
+
+// NewStatsHook returns a StatsHook ready to register with AddHook.
+func NewStatsHook() *StatsHook {
+	return &StatsHook{latency: make(map[string][]int64)}
+}
+
+// Fire implements Hook.
+func (h *StatsHook) Fire(event HookEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch event.State {
+	case P:
+		h.pTotal++
+	case O:
+		h.oTotal++
+	case T:
+		h.tTotal++
+	}
+	h.latency[event.TaskType] = append(h.latency[event.TaskType], event.ElapsedMs)
+}
+
+// Totals returns the cumulative P/O/T counts observed so far.
+func (h *StatsHook) Totals() (pTotal, oTotal, tTotal int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.pTotal, h.oTotal, h.tTotal
+}
+
+// LatencyMs returns the recorded elapsed_ms samples for taskType, in
+// the order observed.
+func (h *StatsHook) LatencyMs(taskType string) []int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	samples := h.latency[taskType]
+	out := make([]int64, len(samples))
+	copy(out, samples)
+	return out
+}
+
+// jsonFileRecord is one line of a JSONFileHook's audit log.
+type jsonFileRecord struct {
+	TaskID    int64     `json:"task_id"`
+	TaskType  string    `json:"task_type"`
+	State     TritValue `json:"state"`
+	CTP       string    `json:"ctp"`
+	ElapsedMs int64     `json:"elapsed_ms"`
+}
+
+// JSONFileHook appends one JSON line per task to an audit log file:
+// CTP header, task ID, task type, and resulting state.
+type JSONFileHook struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewJSONFileHook opens (creating if necessary) path for append-only
+// writes and returns a ready-to-use JSONFileHook.
+func NewJSONFileHook(path string) (*JSONFileHook, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONFileHook{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Fire implements Hook.
+func (h *JSONFileHook) Fire(event HookEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.enc.Encode(jsonFileRecord{
+		TaskID:    event.TaskID,
+		TaskType:  event.TaskType,
+		State:     event.State,
+		CTP:       event.CTP.String(),
+		ElapsedMs: event.ElapsedMs,
+	})
+}
+
+// Close closes the underlying file.
+func (h *JSONFileHook) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.file.Close()
+}
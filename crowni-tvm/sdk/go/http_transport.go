@@ -0,0 +1,311 @@
+package crowny
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ═══════════════════════════════════════════════
+// HTTPTransport
+// ═══════════════════════════════════════════════
+
+// HTTPTransport is the default Transport: JSON over HTTP POST to /run,
+// with multi-endpoint failover following the same pinned-endpoint model
+// as etcd's httpClusterClient.
+type HTTPTransport struct {
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	endpoints   []string
+	pinned      int
+	retryPolicy RetryPolicy
+
+	healthMu sync.RWMutex
+	health   map[string]TritValue
+
+	syncStop chan struct{}
+}
+
+func newHTTPTransport(endpoints []string) *HTTPTransport {
+	eps := make([]string, len(endpoints))
+	health := make(map[string]TritValue, len(endpoints))
+	for i, ep := range endpoints {
+		eps[i] = strings.TrimRight(ep, "/")
+		health[eps[i]] = O
+	}
+	return &HTTPTransport{
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		endpoints:   eps,
+		retryPolicy: DefaultRetryPolicy(),
+		health:      health,
+	}
+}
+
+// Do implements Transport. It attempts the pinned endpoint first and
+// rotates to the next one on connection errors or 5xx responses. A
+// well-formed TritResult{State: T} from the server is a semantic
+// failure, not a transport failure, so it does NOT trigger rotation.
+func (t *HTTPTransport) Do(ctx context.Context, req TaskRequest, ctp CtpHeader) (TaskResponse, CtpHeader, error) {
+	body := map[string]interface{}{
+		"type":    req.Type,
+		"subject": req.Subject,
+		"payload": req.Payload,
+		"params":  req.Params,
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return TaskResponse{State: T, Data: err.Error()}, CtpHeader{}, err
+	}
+
+	endpoints := t.Endpoints()
+	if len(endpoints) == 0 {
+		err := fmt.Errorf("crowny: HTTPTransport has no configured endpoints")
+		return TaskResponse{State: T, Data: err.Error()}, CtpHeader{}, err
+	}
+
+	policy := t.getRetryPolicy()
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = len(endpoints)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		idx, base := t.pinnedEndpoint()
+
+		state, respData, newCtp, retryable, err := t.doRun(ctx, base, data, ctp)
+		if err != nil {
+			if ctx.Err() != nil {
+				return TaskResponse{}, CtpHeader{}, ctx.Err()
+			}
+			t.setHealth(base, T)
+			if retryable {
+				t.rotate(idx)
+				lastErr = err
+				continue
+			}
+			return TaskResponse{State: T, Data: err.Error()}, CtpHeader{}, err
+		}
+
+		t.setHealth(base, P)
+
+		if state == O && policy.shouldRetry(O) && attempt < maxAttempts-1 {
+			t.rotate(idx)
+			lastErr = nil
+			continue
+		}
+
+		return TaskResponse{State: state, Data: respData}, newCtp, nil
+	}
+
+	return TaskResponse{State: T, Data: lastErr.Error()}, CtpHeader{}, lastErr
+}
+
+// doRun performs a single /run attempt against base and classifies the
+// outcome: retryable is true for connection errors and 5xx responses,
+// as opposed to a well-formed TritResult body.
+func (t *HTTPTransport) doRun(ctx context.Context, base string, data []byte, ctp CtpHeader) (state TritValue, respData map[string]interface{}, newCtp CtpHeader, retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", base+"/run", bytes.NewReader(data))
+	if err != nil {
+		return O, nil, CtpHeader{}, false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Crowny-Trit", ctp.String())
+	req.Header.Set("X-Crowny-Version", "1.0")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return O, nil, CtpHeader{}, true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return O, nil, CtpHeader{}, true, fmt.Errorf("%s: %s", base, resp.Status)
+	}
+
+	if ctpStr := resp.Header.Get("X-Crowny-Trit"); ctpStr != "" {
+		newCtp = ParseCtpHeader(ctpStr)
+	}
+
+	respBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(respBody, &respData)
+
+	return parseTritFromResponse(respData), respData, newCtp, false, nil
+}
+
+// Ping implements Pinger by issuing a GET / against the pinned endpoint.
+func (t *HTTPTransport) Ping(ctx context.Context) (TritValue, error) {
+	_, base := t.pinnedEndpoint()
+	return t.pingEndpoint(ctx, base)
+}
+
+func (t *HTTPTransport) pingEndpoint(ctx context.Context, base string) (TritValue, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", base+"/", nil)
+	if err != nil {
+		return T, err
+	}
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return T, err
+	}
+	defer resp.Body.Close()
+	return P, nil
+}
+
+// BaseURL returns the pinned endpoint, used by Client.Watch to derive
+// a websocket URL when no Streamer transport is configured.
+func (t *HTTPTransport) BaseURL() string {
+	_, base := t.pinnedEndpoint()
+	return base
+}
+
+// Endpoints returns the configured endpoints in pinned order.
+func (t *HTTPTransport) Endpoints() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	eps := make([]string, len(t.endpoints))
+	copy(eps, t.endpoints)
+	return eps
+}
+
+// EndpointHealth returns the last-observed health of each endpoint:
+// P (reachable, last call succeeded), O (untested or degraded), or
+// T (last call errored).
+func (t *HTTPTransport) EndpointHealth() map[string]TritValue {
+	t.healthMu.RLock()
+	defer t.healthMu.RUnlock()
+	h := make(map[string]TritValue, len(t.health))
+	for k, v := range t.health {
+		h[k] = v
+	}
+	return h
+}
+
+// SetEndpoints replaces the endpoint list, resetting the pinned index
+// and per-endpoint health to untested (O). An empty endpoints is a
+// no-op; it never leaves the transport with zero endpoints.
+func (t *HTTPTransport) SetEndpoints(endpoints []string) {
+	if len(endpoints) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	eps := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		eps[i] = strings.TrimRight(ep, "/")
+	}
+	t.endpoints = eps
+	t.pinned = 0
+	t.mu.Unlock()
+
+	t.healthMu.Lock()
+	t.health = make(map[string]TritValue, len(eps))
+	for _, ep := range eps {
+		t.health[ep] = O
+	}
+	t.healthMu.Unlock()
+}
+
+// SetRetryPolicy sets the RetryPolicy used by Do.
+func (t *HTTPTransport) SetRetryPolicy(p RetryPolicy) {
+	t.mu.Lock()
+	t.retryPolicy = p
+	t.mu.Unlock()
+}
+
+// getRetryPolicy returns the RetryPolicy currently used by Do.
+func (t *HTTPTransport) getRetryPolicy() RetryPolicy {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.retryPolicy
+}
+
+// Sync pings every configured endpoint once and refreshes EndpointHealth.
+func (t *HTTPTransport) Sync(ctx context.Context) {
+	for _, ep := range t.Endpoints() {
+		state, err := t.pingEndpoint(ctx, ep)
+		if err != nil {
+			t.setHealth(ep, T)
+			continue
+		}
+		t.setHealth(ep, state)
+	}
+}
+
+// StartSync runs Sync in the background on the given interval until the
+// returned stop function is called. Calling StartSync again first stops
+// any previously running loop.
+func (t *HTTPTransport) StartSync(interval time.Duration) (stop func()) {
+	t.mu.Lock()
+	if t.syncStop != nil {
+		close(t.syncStop)
+	}
+	stopCh := make(chan struct{})
+	t.syncStop = stopCh
+	t.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				t.Sync(context.Background())
+			}
+		}
+	}()
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.syncStop == stopCh {
+			close(stopCh)
+			t.syncStop = nil
+		}
+	}
+}
+
+// pinnedEndpoint returns the currently pinned endpoint's index and URL,
+// or (0, "") if no endpoints are configured.
+func (t *HTTPTransport) pinnedEndpoint() (int, string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.endpoints) == 0 {
+		return 0, ""
+	}
+	idx := t.pinned
+	return idx, t.endpoints[idx]
+}
+
+// rotate advances the pinned index past from, the endpoint that just
+// failed. If another goroutine already rotated past it, this is a
+// no-op.
+func (t *HTTPTransport) rotate(from int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.endpoints) == 0 {
+		return
+	}
+	if t.pinned == from {
+		t.pinned = (t.pinned + 1) % len(t.endpoints)
+	}
+}
+
+func (t *HTTPTransport) setHealth(endpoint string, v TritValue) {
+	t.healthMu.Lock()
+	defer t.healthMu.Unlock()
+	if t.health == nil {
+		t.health = make(map[string]TritValue)
+	}
+	t.health[endpoint] = v
+}
@@ -0,0 +1,177 @@
+package crowny
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/cenkalti/rpc2/jsonrpc"
+)
+
+// ═══════════════════════════════════════════════
+// JSONRPCTransport
+// ═══════════════════════════════════════════════
+
+// jsonrpcParams is the JSON-RPC 2.0 params object sent for every call:
+// the CTP header travels as a leading field alongside the task itself,
+// rather than as an out-of-band header.
+type jsonrpcParams struct {
+	Ctp  string      `json:"ctp"`
+	Task TaskRequest `json:"task"`
+}
+
+type jsonrpcResult struct {
+	Ctp    string      `json:"ctp"`
+	State  TritValue   `json:"state"`
+	Data   interface{} `json:"data"`
+	TaskID int64       `json:"task_id"`
+}
+
+// JSONRPCTransport speaks JSON-RPC 2.0 over a persistent, bidirectional
+// connection via github.com/cenkalti/rpc2/jsonrpc. Being bidirectional,
+// it also drives Watch/Stream natively (see Watch below) instead of
+// falling back to a raw websocket dial.
+type JSONRPCTransport struct {
+	client *rpc2.Client
+
+	watchMu  sync.Mutex
+	watchers map[int64]*jsonrpcWatcher
+}
+
+// jsonrpcWatcher is one outstanding Watch call's channel and lifecycle:
+// stop is safe to call more than once (from the terminal-event path and
+// from ctx cancellation racing each other) and closes out exactly once.
+type jsonrpcWatcher struct {
+	out     chan TritEvent
+	done    chan struct{}
+	stopped sync.Once
+}
+
+func newJSONRPCWatcher() *jsonrpcWatcher {
+	return &jsonrpcWatcher{out: make(chan TritEvent), done: make(chan struct{})}
+}
+
+func (w *jsonrpcWatcher) stop() {
+	w.stopped.Do(func() {
+		close(w.done)
+		close(w.out)
+	})
+}
+
+// NewJSONRPCTransport dials addr (host:port) over TCP and returns a
+// ready-to-use JSONRPCTransport. The connection is served in the
+// background for the lifetime of the process; callers needing graceful
+// shutdown should call Close.
+func NewJSONRPCTransport(addr string) (*JSONRPCTransport, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	client := rpc2.NewClientWithCodec(jsonrpc.NewJSONCodec(conn))
+	t := &JSONRPCTransport{client: client, watchers: make(map[int64]*jsonrpcWatcher)}
+	client.Handle("Crowny.Event", t.handleEvent)
+	go client.Run()
+	return t, nil
+}
+
+// Close terminates the underlying connection.
+func (t *JSONRPCTransport) Close() error {
+	return t.client.Close()
+}
+
+// Do implements Transport.
+func (t *JSONRPCTransport) Do(ctx context.Context, req TaskRequest, ctp CtpHeader) (TaskResponse, CtpHeader, error) {
+	params := jsonrpcParams{Ctp: ctp.String(), Task: req}
+	var result jsonrpcResult
+	call := t.client.Go("Crowny.Run", params, &result, nil)
+
+	select {
+	case <-ctx.Done():
+		return TaskResponse{}, CtpHeader{}, ctx.Err()
+	case done := <-call.Done:
+		if done.Error != nil {
+			return TaskResponse{}, CtpHeader{}, done.Error
+		}
+	}
+
+	return TaskResponse{State: result.State, Data: result.Data}, ParseCtpHeader(result.Ctp), nil
+}
+
+// Ping implements Pinger.
+func (t *JSONRPCTransport) Ping(ctx context.Context) (TritValue, error) {
+	var ok bool
+	call := t.client.Go("Crowny.Ping", struct{}{}, &ok, nil)
+
+	select {
+	case <-ctx.Done():
+		return T, ctx.Err()
+	case done := <-call.Done:
+		if done.Error != nil {
+			return T, done.Error
+		}
+	}
+	if !ok {
+		return O, nil
+	}
+	return P, nil
+}
+
+// Watch implements Streamer by registering taskID against the single
+// Crowny.Event handler installed in NewJSONRPCTransport, rather than
+// calling t.client.Handle itself: rpc2.Client.Handle panics with
+// "multiple registrations" if called twice for the same method name, so
+// a per-Watch Handle call would panic the second time any task was
+// watched over the life of a transport.
+func (t *JSONRPCTransport) Watch(ctx context.Context, taskID int64) (<-chan TritEvent, error) {
+	w := newJSONRPCWatcher()
+
+	t.watchMu.Lock()
+	t.watchers[taskID] = w
+	t.watchMu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			t.unregisterWatcher(taskID, w)
+		case <-w.done:
+		}
+	}()
+
+	return w.out, nil
+}
+
+// handleEvent is Crowny.Event's single persistent handler, dispatching
+// each notification to the watcher registered for its TaskID.
+func (t *JSONRPCTransport) handleEvent(c *rpc2.Client, ev *TritEvent, reply *struct{}) error {
+	t.watchMu.Lock()
+	w, ok := t.watchers[ev.TaskID]
+	t.watchMu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	select {
+	case w.out <- *ev:
+	case <-w.done:
+		return nil
+	}
+
+	if ev.IsTerminal() {
+		t.unregisterWatcher(ev.TaskID, w)
+	}
+	return nil
+}
+
+// unregisterWatcher removes w from the watchers map (if it's still the
+// current watcher for taskID) and stops it. Safe to call concurrently
+// from both the ctx-cancellation goroutine and handleEvent's
+// terminal-event path.
+func (t *JSONRPCTransport) unregisterWatcher(taskID int64, w *jsonrpcWatcher) {
+	t.watchMu.Lock()
+	if t.watchers[taskID] == w {
+		delete(t.watchers, taskID)
+	}
+	t.watchMu.Unlock()
+	w.stop()
+}
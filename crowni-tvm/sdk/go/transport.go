@@ -0,0 +1,55 @@
+package crowny
+
+import "context"
+
+// ═══════════════════════════════════════════════
+// Transport
+// ═══════════════════════════════════════════════
+
+// TaskRequest is the wire-agnostic representation of a Submit call.
+type TaskRequest struct {
+	Type    string
+	Subject string
+	Payload string
+	Params  map[string]string
+}
+
+// TaskResponse is the wire-agnostic result of a TaskRequest.
+type TaskResponse struct {
+	State TritValue
+	Data  interface{}
+}
+
+// Transport decouples Client from any one wire protocol. HTTPTransport
+// is the default; JSONRPCTransport lets embedders avoid a net/http
+// dependency entirely. A third, gRPC-based transport is still
+// outstanding (see the TODO(chunk0-4) in crowny.proto) pending a
+// committed crownypb package. ctp is the Client's current CTP header
+// on the outbound side; the returned CtpHeader is the server's updated
+// header, or the zero CtpHeader if the transport has none to report.
+type Transport interface {
+	Do(ctx context.Context, req TaskRequest, ctp CtpHeader) (TaskResponse, CtpHeader, error)
+}
+
+// Pinger is implemented by transports that can answer a lightweight
+// liveness check without submitting a task.
+type Pinger interface {
+	Ping(ctx context.Context) (TritValue, error)
+}
+
+// Streamer is implemented by transports that can drive Watch/Stream
+// themselves (JSON-RPC and gRPC are bidirectional) rather than relying
+// on Client's raw websocket fallback.
+type Streamer interface {
+	Watch(ctx context.Context, taskID int64) (<-chan TritEvent, error)
+}
+
+// Option configures a Client at construction time.
+type Option func(*Client)
+
+// WithTransport overrides the Client's Transport. The baseURL passed to
+// NewClient is still used to seed an HTTPTransport, which this Option
+// then replaces.
+func WithTransport(t Transport) Option {
+	return func(c *Client) { c.transport = t }
+}
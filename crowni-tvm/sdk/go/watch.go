@@ -0,0 +1,212 @@
+package crowny
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ═══════════════════════════════════════════════
+// Streaming task events
+// ═══════════════════════════════════════════════
+
+// defaultMaxFrameBytes is large enough for a long LLM streaming
+// response; the websocket default of 64 KiB truncates those.
+const defaultMaxFrameBytes = 1 << 20 // 1 MiB
+
+const (
+	watchInitialBackoff = 500 * time.Millisecond
+	watchMaxBackoff     = 30 * time.Second
+)
+
+// TritEvent is one frame of a watched task's progress.
+type TritEvent struct {
+	State  TritValue   `json:"state"`
+	Trits  []TritValue `json:"trits"`
+	Data   interface{} `json:"data"`
+	TaskID int64       `json:"task_id"`
+	Seq    int64       `json:"seq"`
+}
+
+// IsTerminal reports whether this event concludes the task.
+func (e TritEvent) IsTerminal() bool {
+	return e.State == P || e.State == T
+}
+
+// WithMaxFrameBytes sets the maximum websocket frame size accepted by
+// Watch/Stream. Defaults to 1 MiB so long LLM streaming responses
+// aren't truncated.
+func (c *Client) WithMaxFrameBytes(n int) *Client {
+	c.maxFrameBytes = n
+	return c
+}
+
+func (c *Client) maxFrameBytesOrDefault() int {
+	if c.maxFrameBytes > 0 {
+		return c.maxFrameBytes
+	}
+	return defaultMaxFrameBytes
+}
+
+// Watch opens a streaming connection and emits TritEvent frames for
+// taskID as the server produces them. The channel closes after a
+// terminal event (State P or T), or when ctx is canceled.
+//
+// If the Client's Transport implements Streamer (JSONRPCTransport is
+// bidirectional and drives this natively), Watch delegates to it.
+// Otherwise it falls back to a raw websocket
+// connection against the HTTPTransport's pinned endpoint's /watch
+// route, reconnecting with exponential backoff and resuming from the
+// last-seen Seq.
+func (c *Client) Watch(ctx context.Context, taskID int64) (<-chan TritEvent, error) {
+	if s, ok := c.transport.(Streamer); ok {
+		return s.Watch(ctx, taskID)
+	}
+
+	t, ok := c.transport.(*HTTPTransport)
+	if !ok {
+		return nil, fmt.Errorf("crowny: transport %T does not support Watch", c.transport)
+	}
+
+	wsURL, err := toWebsocketURL(t.BaseURL())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan TritEvent)
+	go c.watchLoop(ctx, wsURL, taskID, out)
+	return out, nil
+}
+
+// Stream submits a task and watches its progress, combining Submit and
+// Watch for long-running execute/compile/llm tasks.
+func (c *Client) Stream(ctx context.Context, taskType, subject, payload string, params map[string]string) (<-chan TritEvent, error) {
+	result, err := c.SubmitContext(ctx, taskType, subject, payload, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.Watch(ctx, result.TaskID)
+}
+
+func (c *Client) watchLoop(ctx context.Context, wsURL string, taskID int64, out chan<- TritEvent) {
+	defer close(out)
+
+	var lastSeq int64
+	backoff := watchInitialBackoff
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		conn, err := c.dialWatch(ctx, wsURL, taskID, lastSeq)
+		if err != nil {
+			if !sleepOrDone(ctx, backoff) {
+				return
+			}
+			backoff = minDuration(backoff*2, watchMaxBackoff)
+			continue
+		}
+		backoff = watchInitialBackoff
+
+		terminal, err := c.readEvents(ctx, conn, &lastSeq, out)
+		conn.Close()
+		if terminal {
+			return
+		}
+		if err != nil {
+			if !sleepOrDone(ctx, backoff) {
+				return
+			}
+			backoff = minDuration(backoff*2, watchMaxBackoff)
+		}
+	}
+}
+
+func (c *Client) dialWatch(ctx context.Context, wsURL string, taskID, lastSeq int64) (*websocket.Conn, error) {
+	u := fmt.Sprintf("%s/watch?task_id=%d&since_seq=%d", wsURL, taskID, lastSeq)
+
+	header := http.Header{}
+	header.Set("X-Crowny-Trit", c.ctpSnapshot().String())
+
+	dialer := websocket.Dialer{
+		ReadBufferSize: c.maxFrameBytesOrDefault(),
+	}
+	conn, resp, err := dialer.DialContext(ctx, u, header)
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil {
+		if ctpStr := resp.Header.Get("X-Crowny-Trit"); ctpStr != "" {
+			c.setCtp(ParseCtpHeader(ctpStr))
+		}
+	}
+	conn.SetReadLimit(int64(c.maxFrameBytesOrDefault()))
+	return conn, nil
+}
+
+func (c *Client) readEvents(ctx context.Context, conn *websocket.Conn, lastSeq *int64, out chan<- TritEvent) (terminal bool, err error) {
+	for {
+		if ctx.Err() != nil {
+			return true, ctx.Err()
+		}
+
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return false, err
+		}
+
+		var ev TritEvent
+		if err := json.Unmarshal(raw, &ev); err != nil {
+			continue
+		}
+		*lastSeq = ev.Seq
+
+		select {
+		case out <- ev:
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+
+		if ev.IsTerminal() {
+			return true, nil
+		}
+	}
+}
+
+func toWebsocketURL(base string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	return u.String(), nil
+}
+
+func sleepOrDone(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}